@@ -1,8 +1,10 @@
 package console
 
 import (
+	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/reeflective/readline"
 	"github.com/reeflective/readline/inputrc"
@@ -33,25 +35,63 @@ type Console struct {
 	// PreCmdRunLineHooks - Same as PreCmdRunHooks, but will have an effect on the
 	// input line being ultimately provided to the command parser. This might
 	// be used by people who want to apply supplemental, specific processing
-	// on the command input line.
-	PreCmdRunLineHooks []func(raw []string) (args []string, err error)
+	// on the command input line. The returned args replace ctx.Args for the
+	// rest of the invocation.
+	PreCmdRunLineHooks []func(ctx *Context) (args []string, err error)
 
 	// PreCmdRunHooks - Once the user has entered a command, but before executing
 	// the target command, the console will execute every function in this list.
 	// These hooks are distinct from the cobra.PreRun() or OnInitialize hooks,
 	// and might be used in combination with them.
-	PreCmdRunHooks []func()
+	PreCmdRunHooks []func(ctx *Context)
 
 	// PostCmdRunHooks are run after the target cobra command has been executed.
 	// These hooks are distinct from the cobra.PreRun() or OnFinalize hooks,
 	// and might be used in combination with them.
-	PostCmdRunHooks []func()
+	PostCmdRunHooks []func(ctx *Context)
+
+	// AfterCmdRunHooks are run after the target command has returned, and unlike
+	// PostCmdRunHooks they are passed the full outcome of the invocation: the raw
+	// command line, the argument list handed to the parser, how long the command took to run, and
+	// the error it returned (if any). This is the hook to use for anything that
+	// needs to react to success/failure or timing, such as a tooltip prompt
+	// showing "$? / 1.42s".
+	AfterCmdRunHooks []func(cmd string, args []string, duration time.Duration, err error)
 
 	// True if the console is currently running a command. This is used by
 	// the various asynchronous log/message functions, which need to adapt their
 	// behavior (do we reprint the prompt, where, etc) based on this.
 	isExecuting bool
 
+	// lastCmdDuration is how long the most recently executed command took to
+	// run, as measured around the menu parser's Parse call in execute().
+	lastCmdDuration time.Duration
+
+	// interruptHandler, if set, is called by Start() whenever the user
+	// presses Ctrl-C while reading input; it returns whether the console
+	// should keep running. interruptCount is reset to 0 whenever a
+	// command executes normally.
+	interruptHandler func(c *Console, count int, line string) bool
+	interruptCount   int
+
+	// eofHandler, if set, is called by Start() whenever the user presses
+	// Ctrl-D on an empty input line; it returns whether the console
+	// should keep running.
+	eofHandler func(c *Console) bool
+
+	// data is the session-wide key/value bag, visible from every Context
+	// regardless of which command or menu produced it. Unlike a Context's
+	// own bag, values here outlive a single command invocation.
+	data map[string]any
+
+	// transcript, if non-nil, is the file every command invocation is
+	// being recorded to. See StartTranscript/StopTranscript.
+	transcript *os.File
+
+	// statusLine, if set, computes a right-hand-side prompt segment that
+	// the shell itself redraws. See SetStatusLine.
+	statusLine func() string
+
 	// concurrency management.
 	mutex *sync.RWMutex
 
@@ -102,6 +142,16 @@ func (c *Console) Shell() *readline.Shell {
 	return c.shell
 }
 
+// LastCommandDuration returns how long the most recently executed command
+// took to run, measured from the moment its arguments were handed to the
+// menu's parser to the moment the parser returned. It is zero until a first command has run.
+func (c *Console) LastCommandDuration() time.Duration {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.lastCmdDuration
+}
+
 // SetPrintLogo - Sets the function that will be called to print the logo.
 func (c *Console) SetPrintLogo(f func(c *Console)) {
 	c.printLogo = f
@@ -204,3 +254,15 @@ func (c *Console) activeMenu() *Menu {
 	// Else return the default menu.
 	return c.menus[""]
 }
+
+// menuName returns the name under which menu is registered, or "" if it is
+// not one of this console's menus.
+func (c *Console) menuName(menu *Menu) string {
+	for name, candidate := range c.menus {
+		if candidate == menu {
+			return name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,132 @@
+package console
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// Context is passed to PreCmdRunLineHooks, PreCmdRunHooks, PostCmdRunHooks
+// and to the menu's Parser, for a single command invocation. The cobra
+// backend hands it on to command handlers as their cmd.Context(); the
+// go-flags backend does not pass it to its handlers. It embeds a
+// context.Context that is cancelled as soon as the user presses Ctrl-C
+// while the command is running, and carries a key/value bag scoped to
+// this one invocation only (for the console's session-wide equivalent,
+// see Console.Set/Get/Del).
+type Context struct {
+	context.Context
+
+	// Raw is the unprocessed command line, as entered by the user.
+	Raw []string
+
+	// Args is the argument list that will be handed to the command parser.
+	// PreCmdRunLineHooks may replace it before the command actually runs.
+	Args []string
+
+	// Menu is the menu that was active when the command was invoked.
+	Menu *Menu
+
+	cancel context.CancelFunc
+	mutex  sync.RWMutex
+	values map[string]any
+}
+
+// newContext builds the per-invocation Context for a command about to be
+// dispatched from the given menu.
+func newContext(menu *Menu, raw []string) *Context {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	args := make([]string, len(raw))
+	copy(args, raw)
+
+	return &Context{
+		Context: ctx,
+		Raw:     raw,
+		Args:    args,
+		Menu:    menu,
+		cancel:  cancel,
+		values:  make(map[string]any),
+	}
+}
+
+// Set stores a value in the invocation-scoped bag, overwriting any value
+// previously stored under the same key.
+func (ctx *Context) Set(key string, val any) {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	ctx.values[key] = val
+}
+
+// Get retrieves a value previously stored with Set, or nil if no value
+// has been stored under that key.
+func (ctx *Context) Get(key string) any {
+	ctx.mutex.RLock()
+	defer ctx.mutex.RUnlock()
+
+	return ctx.values[key]
+}
+
+// Del removes a value from the invocation-scoped bag.
+func (ctx *Context) Del(key string) {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+
+	delete(ctx.values, key)
+}
+
+// watchInterrupt cancels ctx as soon as the process receives an interrupt
+// signal (Ctrl-C) while it is running. The returned stop function must be
+// called once the command returns, so that later Ctrl-C presses go back to
+// being handled by the normal read-loop interrupt handler.
+func (ctx *Context) watchInterrupt() (stop func()) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-sigCh:
+			ctx.cancel()
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(sigCh)
+		close(done)
+	}
+}
+
+// Set stores a value in the console's session-wide bag. Unlike a Context's
+// bag, values stored here persist across command invocations and menus.
+func (c *Console) Set(key string, val any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.data == nil {
+		c.data = make(map[string]any)
+	}
+
+	c.data[key] = val
+}
+
+// Get retrieves a value previously stored with Console.Set, or nil if no
+// value has been stored under that key.
+func (c *Console) Get(key string) any {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.data[key]
+}
+
+// Del removes a value from the console's session-wide bag.
+func (c *Console) Del(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.data, key)
+}
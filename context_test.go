@@ -0,0 +1,67 @@
+package console
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestContextSetGetDel(t *testing.T) {
+	ctx := newContext(nil, []string{"cmd", "arg"})
+
+	if got := ctx.Get("missing"); got != nil {
+		t.Errorf("Get(missing) = %v, want nil", got)
+	}
+
+	ctx.Set("key", 42)
+	if got := ctx.Get("key"); got != 42 {
+		t.Errorf("Get(key) = %v, want 42", got)
+	}
+
+	ctx.Set("key", "overwritten")
+	if got := ctx.Get("key"); got != "overwritten" {
+		t.Errorf("Get(key) after overwrite = %v, want %q", got, "overwritten")
+	}
+
+	ctx.Del("key")
+	if got := ctx.Get("key"); got != nil {
+		t.Errorf("Get(key) after Del = %v, want nil", got)
+	}
+}
+
+func TestContextSetGetConcurrent(t *testing.T) {
+	ctx := newContext(nil, nil)
+
+	const goroutines = 50
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+			ctx.Set("shared", i)
+			ctx.Get("shared")
+			ctx.Del("shared")
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func TestConsoleSetGetDel(t *testing.T) {
+	c := &Console{mutex: &sync.RWMutex{}}
+
+	if got := c.Get("missing"); got != nil {
+		t.Errorf("Get(missing) = %v, want nil", got)
+	}
+
+	c.Set("key", "value")
+	if got := c.Get("key"); got != "value" {
+		t.Errorf("Get(key) = %v, want %q", got, "value")
+	}
+
+	c.Del("key")
+	if got := c.Get("key"); got != nil {
+		t.Errorf("Get(key) after Del = %v, want nil", got)
+	}
+}
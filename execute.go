@@ -1,9 +1,9 @@
-package gonsole
+package console
 
 import (
 	"fmt"
-
-	"github.com/jessevdk/go-flags"
+	"strings"
+	"time"
 )
 
 // execute - The user has entered a command input line, the arguments
@@ -19,28 +19,95 @@ func (c *Console) execute(args []string) {
 		c.isExecuting = false
 	}()
 
-	// Execute the command line.
-	result, err := c.parser.ParseArgs(args)
+	c.mutex.Lock()
+	c.interruptCount = 0
+	c.mutex.Unlock()
 
-	// Process the errors raised by the parser.
-	// A few of them are not really errors, and trigger some stuff.
-	if err != nil {
-		if err == nil {
+	// Build the per-invocation context, and let it be cancelled if the
+	// user presses Ctrl-C while the command below is running.
+	ctx := newContext(c.CurrentMenu(), args)
+	stopWatching := ctx.watchInterrupt()
+	defer stopWatching()
+
+	for _, hook := range c.PreCmdRunLineHooks {
+		newArgs, err := hook(ctx)
+		if err != nil {
+			fmt.Println(parserError + err.Error())
 			return
 		}
-		parserErr, ok := err.(*flags.Error)
-		if !ok {
-			return
+
+		ctx.Args = newArgs
+	}
+
+	for _, hook := range c.PreCmdRunHooks {
+		hook(ctx)
+	}
+
+	// Execute the command line through the menu's parser, timing it so
+	// that AfterCmdRunHooks and LastCommandDuration can report how long
+	// it took, and capturing its output in case a transcript is being
+	// recorded.
+	parser := ctx.Menu.Parser
+
+	c.mutex.RLock()
+	recording := c.transcript != nil
+	c.mutex.RUnlock()
+
+	var finishCapture func() string
+	if recording {
+		finishCapture = captureOutput()
+	}
+
+	started := time.Now()
+	result, err := parser.Parse(ctx)
+	duration := time.Since(started)
+
+	var output string
+	if finishCapture != nil {
+		output = finishCapture()
+	}
+
+	c.mutex.Lock()
+	c.lastCmdDuration = duration
+	c.mutex.Unlock()
+
+	if recording {
+		exitErr := ""
+		if err != nil {
+			exitErr = err.Error()
 		}
 
-		// If the error type is a detected -h, --help flag, print custom help.
-		if parserErr.Type == flags.ErrHelp {
-			c.handleHelpFlag(result)
+		c.recordTranscript(transcriptEntry{
+			TS:         started,
+			Menu:       c.menuName(ctx.Menu),
+			Line:       strings.Join(ctx.Raw, " "),
+			DurationMS: duration.Milliseconds(),
+			ExitErr:    exitErr,
+			Output:     output,
+		})
+	}
+
+	cmd := strings.Join(ctx.Raw, " ")
+
+	for _, hook := range c.AfterCmdRunHooks {
+		hook(cmd, ctx.Args, duration, err)
+	}
+
+	for _, hook := range c.PostCmdRunHooks {
+		hook(ctx)
+	}
+
+	// Process the errors raised by the parser.
+	// A few of them are not really errors, and trigger some stuff.
+	if err != nil {
+		// If the error is a detected -h, --help flag, print custom help.
+		if parser.IsHelp(err) {
+			parser.Help(result)
 			return
 		}
 
 		// Else, we print the raw parser error
-		fmt.Println(parserError + parserErr.Error())
+		fmt.Println(parserError + err.Error())
 	}
 
 	return
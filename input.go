@@ -0,0 +1,129 @@
+package console
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ReadLine prompts the user for a single line of input and returns it once
+// Enter is pressed. It is safe to call from within a command handler: for
+// the duration of the read it keeps the console's isExecuting flag set,
+// which the asynchronous message printers consult, and restores the flag's
+// previous value once the read returns.
+func (c *Console) ReadLine(prompt string) (string, error) {
+	c.mutex.Lock()
+	wasExecuting := c.isExecuting
+	c.isExecuting = true
+	c.mutex.Unlock()
+
+	defer func() {
+		c.mutex.Lock()
+		c.isExecuting = wasExecuting
+		c.mutex.Unlock()
+	}()
+
+	c.shell.Prompt.Primary(func() string { return prompt })
+	defer c.reloadConfig()
+
+	return c.shell.Readline()
+}
+
+// ReadPassword behaves like ReadLine, except that the input is masked as it
+// is typed, using the underlying readline shell's password mode.
+func (c *Console) ReadPassword(prompt string) (string, error) {
+	c.mutex.Lock()
+	wasExecuting := c.isExecuting
+	c.isExecuting = true
+	c.mutex.Unlock()
+
+	defer func() {
+		c.mutex.Lock()
+		c.isExecuting = wasExecuting
+		c.mutex.Unlock()
+	}()
+
+	c.shell.Prompt.Primary(func() string { return prompt })
+	defer c.reloadConfig()
+
+	c.shell.Config.Set("passwd-mode", true)
+	defer c.shell.Config.Set("passwd-mode", false)
+
+	return c.shell.Readline()
+}
+
+// Confirm asks the user a yes/no question and returns their answer. An
+// empty response yields def. Anything else is re-asked until the user
+// answers with some form of yes/no.
+func (c *Console) Confirm(prompt string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+
+	for {
+		answer, err := c.ReadLine(fmt.Sprintf("%s [%s] ", prompt, hint))
+		if err != nil {
+			return false, err
+		}
+
+		if value, ok := parseYesNo(answer, def); ok {
+			return value, nil
+		}
+
+		fmt.Println("Please answer yes or no.")
+	}
+}
+
+// parseYesNo interprets a raw Confirm answer. An empty (whitespace-only)
+// answer resolves to def. It reports ok=false for anything it doesn't
+// recognize, so the caller can re-prompt.
+func parseYesNo(answer string, def bool) (value bool, ok bool) {
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "":
+		return def, true
+	case "y", "yes":
+		return true, true
+	case "n", "no":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// Choose prints a numbered list of options and asks the user to pick one,
+// returning its index into options. It re-asks until a valid choice is made.
+func (c *Console) Choose(prompt string, options []string) (int, error) {
+	fmt.Println(prompt)
+
+	for i, option := range options {
+		fmt.Printf("  %d) %s\n", i+1, option)
+	}
+
+	for {
+		answer, err := c.ReadLine(fmt.Sprintf("Choose [1-%d]: ", len(options)))
+		if err != nil {
+			return -1, err
+		}
+
+		index, ok := parseChoice(answer, len(options))
+		if !ok {
+			fmt.Println("Please enter a valid choice.")
+			continue
+		}
+
+		return index, nil
+	}
+}
+
+// parseChoice interprets a raw Choose answer as a 1-based option number and
+// returns its 0-based index. It reports ok=false if the answer isn't a
+// number, or is out of the [1, count] range.
+func parseChoice(answer string, count int) (index int, ok bool) {
+	choice, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || choice < 1 || choice > count {
+		return -1, false
+	}
+
+	return choice - 1, true
+}
@@ -0,0 +1,56 @@
+package console
+
+import "testing"
+
+func TestParseYesNo(t *testing.T) {
+	tests := []struct {
+		answer  string
+		def     bool
+		wantVal bool
+		wantOk  bool
+	}{
+		{"", true, true, true},
+		{"", false, false, true},
+		{"  ", true, true, true},
+		{"y", false, true, true},
+		{"Y", false, true, true},
+		{"yes", false, true, true},
+		{"YES", false, true, true},
+		{"n", true, false, true},
+		{"no", true, false, true},
+		{"maybe", true, false, false},
+	}
+
+	for _, tt := range tests {
+		val, ok := parseYesNo(tt.answer, tt.def)
+		if val != tt.wantVal || ok != tt.wantOk {
+			t.Errorf("parseYesNo(%q, %v) = (%v, %v), want (%v, %v)",
+				tt.answer, tt.def, val, ok, tt.wantVal, tt.wantOk)
+		}
+	}
+}
+
+func TestParseChoice(t *testing.T) {
+	tests := []struct {
+		answer    string
+		count     int
+		wantIndex int
+		wantOk    bool
+	}{
+		{"1", 3, 0, true},
+		{"3", 3, 2, true},
+		{" 2 ", 3, 1, true},
+		{"0", 3, -1, false},
+		{"4", 3, -1, false},
+		{"abc", 3, -1, false},
+		{"", 3, -1, false},
+	}
+
+	for _, tt := range tests {
+		index, ok := parseChoice(tt.answer, tt.count)
+		if index != tt.wantIndex || ok != tt.wantOk {
+			t.Errorf("parseChoice(%q, %d) = (%d, %v), want (%d, %v)",
+				tt.answer, tt.count, index, ok, tt.wantIndex, tt.wantOk)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package console
+
+// Parser decouples the console from any single argument-parsing backend,
+// so that different menus can even use different parsers (one built on
+// go-flags, another on cobra, etc). See parsers/goflags and parsers/cobra
+// for the two backends shipped alongside this module.
+type Parser interface {
+	// Parse parses and dispatches ctx.Args against the parser's command
+	// tree. result is an implementation-specific value (eg. the
+	// underlying *flags.Parser or the matched *cobra.Command) that Help
+	// knows how to render.
+	Parse(ctx *Context) (result any, err error)
+
+	// Complete returns completion candidates for a partially typed
+	// command line.
+	Complete(args []string) []Completion
+
+	// Help renders help output for a Parse call whose error satisfied IsHelp.
+	Help(result any)
+
+	// IsHelp reports whether err is this backend's sentinel "the user
+	// asked for -h/--help" error.
+	IsHelp(err error) bool
+}
+
+// Completion is a single completion candidate returned by a Parser.
+type Completion struct {
+	Value       string
+	Description string
+}
@@ -0,0 +1,68 @@
+// Package cobra implements console.Parser on top of a *cobra.Command root,
+// so that a Menu can be driven by cobra's PreRun/RunE/PostRun idioms instead
+// of go-flags.
+package cobra
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/srliao/console"
+)
+
+// Parser adapts a *cobra.Command root to the console.Parser interface.
+type Parser struct {
+	root *cobra.Command
+}
+
+// New wraps root so it can be assigned to a Menu's Parser field. It forces
+// root.SilenceErrors and root.SilenceUsage on: the console, not cobra, is
+// the single renderer of parser errors, matching the go-flags backend,
+// which never prints on its own either.
+func New(root *cobra.Command) *Parser {
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	return &Parser{root: root}
+}
+
+// Parse implements console.Parser. It dispatches ctx.Args against the
+// cobra command tree, honoring whatever PreRun/RunE/PostRun the matched
+// command defines. Cobra resolves and prints -h/--help itself against the
+// correct (sub)command before ever reaching RunE, so Parse does not need
+// to special-case it: a help invocation simply comes back with a nil error
+// and the matched command, having already printed its own help.
+func (p *Parser) Parse(ctx *console.Context) (any, error) {
+	p.root.SetArgs(ctx.Args)
+
+	cmd, err := p.root.ExecuteContextC(ctx)
+	if cmd == nil {
+		cmd = p.root
+	}
+
+	return cmd, err
+}
+
+// Complete implements console.Parser. Completion for cobra menus is
+// handled by the console's own completer, so this is a no-op.
+func (p *Parser) Complete(args []string) []console.Completion {
+	return nil
+}
+
+// Help implements console.Parser. It renders the help of the command that
+// Parse returned, or of the root command if result is not a *cobra.Command.
+// Since IsHelp always reports false, the console itself never calls it.
+func (p *Parser) Help(result any) {
+	if cmd, ok := result.(*cobra.Command); ok && cmd != nil {
+		cmd.Help()
+		return
+	}
+
+	p.root.Help()
+}
+
+// IsHelp implements console.Parser. Cobra handles -h/--help natively
+// inside Parse and returns a nil error for it, so no error ever reaches
+// here as a help request.
+func (p *Parser) IsHelp(err error) bool {
+	return false
+}
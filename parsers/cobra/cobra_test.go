@@ -0,0 +1,64 @@
+package cobra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/srliao/console"
+)
+
+func newTestRoot() *cobra.Command {
+	root := &cobra.Command{Use: "root", Run: func(cmd *cobra.Command, args []string) {}}
+	sub := &cobra.Command{Use: "sub", Run: func(cmd *cobra.Command, args []string) {}}
+	root.AddCommand(sub)
+
+	return root
+}
+
+func TestParseResolvesSubcommand(t *testing.T) {
+	root := newTestRoot()
+	p := New(root)
+
+	result, err := p.Parse(&console.Context{Context: context.Background(), Args: []string{"sub"}})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	cmd, ok := result.(*cobra.Command)
+	if !ok {
+		t.Fatalf("Parse result is %T, want *cobra.Command", result)
+	}
+
+	if cmd.Name() != "sub" {
+		t.Errorf("Parse resolved command %q, want %q", cmd.Name(), "sub")
+	}
+}
+
+func TestParseHelpResolvesSubcommand(t *testing.T) {
+	root := newTestRoot()
+	p := New(root)
+
+	result, err := p.Parse(&console.Context{Context: context.Background(), Args: []string{"sub", "--help"}})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	cmd, ok := result.(*cobra.Command)
+	if !ok {
+		t.Fatalf("Parse result is %T, want *cobra.Command", result)
+	}
+
+	if cmd.Name() != "sub" {
+		t.Errorf("--help on subcommand resolved to %q, want %q (regression: used to always be root)", cmd.Name(), "sub")
+	}
+}
+
+func TestParserIsHelpAlwaysFalse(t *testing.T) {
+	p := New(newTestRoot())
+
+	if p.IsHelp(nil) {
+		t.Errorf("IsHelp(nil) = true, want false")
+	}
+}
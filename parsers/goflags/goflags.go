@@ -0,0 +1,54 @@
+// Package goflags implements console.Parser on top of
+// github.com/jessevdk/go-flags, preserving the console's original parsing
+// behavior from before menus could choose their own backend.
+package goflags
+
+import (
+	"os"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/srliao/console"
+)
+
+// Parser adapts an existing *flags.Parser to the console.Parser interface.
+type Parser struct {
+	parser *flags.Parser
+}
+
+// New wraps parser so it can be assigned to a Menu's Parser field.
+func New(parser *flags.Parser) *Parser {
+	return &Parser{parser: parser}
+}
+
+// Parse implements console.Parser. The returned result is the wrapped
+// *flags.Parser itself, so that Help (or any other caller relying on the
+// console.Parser doc) can render help off of it.
+func (p *Parser) Parse(ctx *console.Context) (any, error) {
+	_, err := p.parser.ParseArgs(ctx.Args)
+
+	return p.parser, err
+}
+
+// Complete implements console.Parser. Completion for go-flags menus is
+// handled by the console's own completer, so this is a no-op.
+func (p *Parser) Complete(args []string) []console.Completion {
+	return nil
+}
+
+// Help implements console.Parser.
+func (p *Parser) Help(result any) {
+	parser, ok := result.(*flags.Parser)
+	if !ok || parser == nil {
+		parser = p.parser
+	}
+
+	parser.WriteHelp(os.Stdout)
+}
+
+// IsHelp implements console.Parser.
+func (p *Parser) IsHelp(err error) bool {
+	flagsErr, ok := err.(*flags.Error)
+
+	return ok && flagsErr.Type == flags.ErrHelp
+}
@@ -0,0 +1,26 @@
+package goflags
+
+import (
+	"errors"
+	"testing"
+
+	flags "github.com/jessevdk/go-flags"
+)
+
+func TestParserIsHelp(t *testing.T) {
+	p := New(flags.NewParser(&struct{}{}, flags.Default))
+
+	helpErr := &flags.Error{Type: flags.ErrHelp, Message: "help requested"}
+	if !p.IsHelp(helpErr) {
+		t.Errorf("IsHelp(%v) = false, want true", helpErr)
+	}
+
+	otherFlagsErr := &flags.Error{Type: flags.ErrUnknownFlag, Message: "unknown flag"}
+	if p.IsHelp(otherFlagsErr) {
+		t.Errorf("IsHelp(%v) = true, want false", otherFlagsErr)
+	}
+
+	if p.IsHelp(errors.New("some other error")) {
+		t.Errorf("IsHelp(plain error) = true, want false")
+	}
+}
@@ -0,0 +1,143 @@
+package console
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// progressBarWidth is the number of characters used to draw the filled
+// portion of a ProgressBar.
+const progressBarWidth = 30
+
+// ProgressBar is a goroutine-safe progress indicator meant to be driven
+// from within a command handler, or from any goroutine it spawns, while
+// the console is executing. Updates are serialized against the console's
+// output mutex, but are only ever drawn while isExecuting is still true:
+// this makes it safe to update a ProgressBar from a background goroutine
+// started by the handler, but, like the asynchronous log printers, it is
+// not meant to keep drawing once the handler has returned and the console
+// is back to showing the prompt.
+type ProgressBar struct {
+	console *Console
+
+	mutex   sync.Mutex
+	total   int64
+	current int64
+	desc    string
+	done    bool
+}
+
+// NewProgressBar creates a progress bar for a task of the given total size
+// (pass 0 if the total is not known in advance) and draws it immediately.
+func (c *Console) NewProgressBar(total int64, desc string) *ProgressBar {
+	bar := &ProgressBar{
+		console: c,
+		total:   total,
+		desc:    desc,
+	}
+
+	bar.mutex.Lock()
+	bar.draw()
+	bar.mutex.Unlock()
+
+	return bar
+}
+
+// Add advances the progress bar by n and redraws it.
+func (p *ProgressBar) Add(n int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.done {
+		return
+	}
+
+	p.current += n
+	p.draw()
+}
+
+// SetDescription changes the label printed alongside the progress bar.
+func (p *ProgressBar) SetDescription(desc string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.done {
+		return
+	}
+
+	p.desc = desc
+	p.draw()
+}
+
+// Finish draws the progress bar one last time at completion, then prevents
+// any further updates from it.
+func (p *ProgressBar) Finish() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.done {
+		return
+	}
+
+	if p.total > 0 {
+		p.current = p.total
+	}
+
+	p.done = true
+	p.draw()
+
+	p.console.mutex.RLock()
+	executing := p.console.isExecuting
+	p.console.mutex.RUnlock()
+
+	if executing {
+		fmt.Println()
+	}
+}
+
+// draw must be called with p.mutex held. It only actually prints while the
+// console is still executing the command that owns this bar: once the
+// handler has returned, the console has gone back to drawing the prompt
+// and is no longer a safe place for the bar to write into.
+func (p *ProgressBar) draw() {
+	p.console.mutex.RLock()
+	executing := p.console.isExecuting
+	p.console.mutex.RUnlock()
+
+	if !executing {
+		return
+	}
+
+	p.console.mutex.Lock()
+	defer p.console.mutex.Unlock()
+
+	if p.total > 0 {
+		filled := int(progressBarWidth * float64(p.current) / float64(p.total))
+		if filled > progressBarWidth {
+			filled = progressBarWidth
+		}
+
+		bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
+		fmt.Printf("\r[%s] %d/%d %s", bar, p.current, p.total, p.desc)
+
+		return
+	}
+
+	fmt.Printf("\r%s... %d", p.desc, p.current)
+}
+
+// SetStatusLine installs a function whose result is rendered as an
+// always-visible right-hand-side prompt segment, the same mechanism the
+// prompt engine itself uses. Because the shell, not our own code, decides
+// when to call it and redraw, a goroutine updating whatever status reads
+// from never races with or corrupts the in-progress input line: it is
+// exactly the same coordination the async log path relies on, just driven
+// by the shell's redraw cycle instead of the log mutex. Pass nil to remove it.
+func (c *Console) SetStatusLine(status func() string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.statusLine = status
+	c.shell.Prompt.Right(status)
+}
@@ -0,0 +1,121 @@
+package console
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/reeflective/readline"
+)
+
+// Start runs the console loop: it repeatedly reads a line from the shell,
+// dispatches it to execute(), and reacts to the readline errors (Ctrl-C,
+// Ctrl-D) through the interrupt and EOF handlers. It returns once the
+// console has been told to stop: either a custom EOF/interrupt handler
+// returned false, the default handlers decided to exit, or Readline failed
+// repeatedly with an error neither handler understands.
+func (c *Console) Start() error {
+	const maxConsecutiveReadErrors = 10
+
+	consecutiveReadErrors := 0
+
+	for {
+		if c.LeaveNewline {
+			fmt.Println()
+		}
+
+		line, err := c.shell.Readline()
+
+		switch {
+		case err == nil:
+			consecutiveReadErrors = 0
+
+		case errors.Is(err, readline.ErrInterrupt):
+			c.interruptCount++
+			if !c.runInterruptHandler(line) {
+				return nil
+			}
+			continue
+
+		case errors.Is(err, io.EOF):
+			if !c.runEOFHandler() {
+				return nil
+			}
+			continue
+
+		default:
+			consecutiveReadErrors++
+			if consecutiveReadErrors >= maxConsecutiveReadErrors {
+				return err
+			}
+			continue
+		}
+
+		args := strings.Fields(line)
+		if len(args) == 0 {
+			continue
+		}
+
+		c.execute(args)
+	}
+}
+
+// SetInterruptHandler sets the function called whenever the user presses
+// Ctrl-C while the console is waiting for input. count is the number of
+// consecutive times Ctrl-C has been pressed (reset by any normally executed
+// command), and line is the (possibly partial) input being edited. Return
+// true to keep the console running, or false to make Start() return. If
+// unset, the console uses a default handler that asks the user to press
+// Ctrl-C again to exit, and does so on the second consecutive press.
+func (c *Console) SetInterruptHandler(handler func(c *Console, count int, line string) bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.interruptHandler = handler
+}
+
+// SetEOFHandler sets the function called whenever the user presses Ctrl-D
+// on an empty input line. Return true to keep the console running, or false
+// to make Start() return. If unset, the console uses a default handler that
+// stops the console.
+func (c *Console) SetEOFHandler(handler func(c *Console) bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.eofHandler = handler
+}
+
+// runInterruptHandler runs the interrupt handler and reports whether the
+// console should keep running.
+func (c *Console) runInterruptHandler(line string) bool {
+	c.mutex.RLock()
+	handler := c.interruptHandler
+	count := c.interruptCount
+	c.mutex.RUnlock()
+
+	if handler != nil {
+		return handler(c, count, line)
+	}
+
+	if count < 2 {
+		fmt.Println("Press Ctrl-C again to exit")
+		return true
+	}
+
+	return false
+}
+
+// runEOFHandler runs the EOF handler and reports whether the console
+// should keep running.
+func (c *Console) runEOFHandler() bool {
+	c.mutex.RLock()
+	handler := c.eofHandler
+	c.mutex.RUnlock()
+
+	if handler != nil {
+		return handler(c)
+	}
+
+	return false
+}
@@ -0,0 +1,209 @@
+package console
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// transcriptEntry is a single JSONL record written by StartTranscript and
+// read back by Replay.
+type transcriptEntry struct {
+	TS         time.Time `json:"ts"`
+	Menu       string    `json:"menu"`
+	Line       string    `json:"line"`
+	DurationMS int64     `json:"duration_ms"`
+	ExitErr    string    `json:"exit_err,omitempty"`
+	Output     string    `json:"output"`
+}
+
+// StartTranscript begins recording every input line and the output it
+// produces to path, as newline-delimited JSON. Recording continues across
+// menus and commands until StopTranscript is called. Starting a transcript
+// while one is already running replaces it.
+func (c *Console) StartTranscript(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.transcript != nil {
+		c.transcript.Close()
+	}
+
+	c.transcript = file
+
+	return nil
+}
+
+// StopTranscript stops any in-progress transcript recording and closes the
+// underlying file. It is a no-op if no transcript is being recorded.
+func (c *Console) StopTranscript() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.transcript == nil {
+		return nil
+	}
+
+	err := c.transcript.Close()
+	c.transcript = nil
+
+	return err
+}
+
+// recordTranscript appends one entry to the active transcript, if any.
+func (c *Console) recordTranscript(entry transcriptEntry) {
+	c.mutex.Lock()
+	file := c.transcript
+	c.mutex.Unlock()
+
+	if file == nil {
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	data = append(data, '\n')
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.transcript != nil {
+		c.transcript.Write(data)
+	}
+}
+
+// captureOutput redirects os.Stdout and os.Stderr to a pair of pipes for
+// the duration of a command, tee-ing everything written to them back to
+// the real stdout/stderr respectively while also collecting it for the
+// transcript. It is only ever called while a transcript is being recorded:
+// the pipes and goroutines it sets up are not free, and normal operation
+// must not pay for them. The returned function restores the original
+// streams and returns what was captured; it must always be called, exactly
+// once.
+func captureOutput() (finish func() string) {
+	realStdout, realStderr := os.Stdout, os.Stderr
+
+	outReader, outWriter, err := os.Pipe()
+	if err != nil {
+		return func() string { return "" }
+	}
+
+	errReader, errWriter, err := os.Pipe()
+	if err != nil {
+		outReader.Close()
+		outWriter.Close()
+		return func() string { return "" }
+	}
+
+	os.Stdout = outWriter
+	os.Stderr = errWriter
+
+	var buf strings.Builder
+	var bufMutex sync.Mutex
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			tee(&buf, &bufMutex, realStdout, outReader)
+		}()
+
+		go func() {
+			defer wg.Done()
+			tee(&buf, &bufMutex, realStderr, errReader)
+		}()
+
+		wg.Wait()
+	}()
+
+	return func() string {
+		os.Stdout = realStdout
+		os.Stderr = realStderr
+		outWriter.Close()
+		errWriter.Close()
+		<-done
+		outReader.Close()
+		errReader.Close()
+
+		return buf.String()
+	}
+}
+
+// tee copies from src to dst while also appending everything read to buf,
+// serialized by bufMutex since stdout and stderr are copied concurrently.
+func tee(buf *strings.Builder, bufMutex *sync.Mutex, dst io.Writer, src io.Reader) {
+	chunk := make([]byte, 4096)
+
+	for {
+		n, err := src.Read(chunk)
+		if n > 0 {
+			dst.Write(chunk[:n])
+
+			bufMutex.Lock()
+			buf.Write(chunk[:n])
+			bufMutex.Unlock()
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Replay feeds the input lines recorded in a transcript file back through
+// the console as if the user had typed them. If speed is greater than 0,
+// the original inter-command delays (scaled by 1/speed) are respected;
+// otherwise lines are replayed back to back.
+func (c *Console) Replay(path string, speed float64) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var previous time.Time
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry transcriptEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return fmt.Errorf("malformed transcript entry: %w", err)
+		}
+
+		if speed > 0 && !previous.IsZero() {
+			time.Sleep(time.Duration(float64(entry.TS.Sub(previous)) / speed))
+		}
+		previous = entry.TS
+
+		if entry.Menu != "" {
+			c.SwitchMenu(entry.Menu)
+		}
+
+		args := strings.Fields(entry.Line)
+		if len(args) == 0 {
+			continue
+		}
+
+		c.execute(args)
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,99 @@
+package console
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTranscriptRoundTrip(t *testing.T) {
+	c := &Console{mutex: &sync.RWMutex{}}
+
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+
+	if err := c.StartTranscript(path); err != nil {
+		t.Fatalf("StartTranscript: %v", err)
+	}
+
+	want := transcriptEntry{
+		TS:         time.Now().Truncate(time.Second),
+		Menu:       "main",
+		Line:       "help -v",
+		DurationMS: 42,
+		Output:     "usage: help [-v]\n",
+	}
+	c.recordTranscript(want)
+
+	if err := c.StopTranscript(); err != nil {
+		t.Fatalf("StopTranscript: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("opening transcript: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		t.Fatalf("expected one transcript line, got none")
+	}
+
+	var got transcriptEntry
+	if err := json.Unmarshal(scanner.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshalling entry: %v", err)
+	}
+
+	if !got.TS.Equal(want.TS) || got.Menu != want.Menu || got.Line != want.Line ||
+		got.DurationMS != want.DurationMS || got.Output != want.Output || got.ExitErr != "" {
+		t.Fatalf("round-tripped entry = %+v, want %+v", got, want)
+	}
+
+	if scanner.Scan() {
+		t.Fatalf("expected exactly one transcript line, got more")
+	}
+}
+
+func TestStartTranscriptReplacesPrevious(t *testing.T) {
+	c := &Console{mutex: &sync.RWMutex{}}
+	dir := t.TempDir()
+
+	first := filepath.Join(dir, "first.jsonl")
+	second := filepath.Join(dir, "second.jsonl")
+
+	if err := c.StartTranscript(first); err != nil {
+		t.Fatalf("StartTranscript(first): %v", err)
+	}
+
+	if err := c.StartTranscript(second); err != nil {
+		t.Fatalf("StartTranscript(second): %v", err)
+	}
+
+	c.recordTranscript(transcriptEntry{Line: "ls"})
+
+	if err := c.StopTranscript(); err != nil {
+		t.Fatalf("StopTranscript: %v", err)
+	}
+
+	data, err := os.ReadFile(first)
+	if err != nil {
+		t.Fatalf("reading first transcript: %v", err)
+	}
+
+	if len(data) != 0 {
+		t.Fatalf("expected replaced transcript %q to stay empty, got %q", first, data)
+	}
+
+	data, err = os.ReadFile(second)
+	if err != nil {
+		t.Fatalf("reading second transcript: %v", err)
+	}
+
+	if len(data) == 0 {
+		t.Fatalf("expected the active transcript %q to contain the recorded entry", second)
+	}
+}